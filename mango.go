@@ -6,7 +6,6 @@
 // manual pages from the source code of your Go commands.
 // It builds manual pages from the comments and flag function calls found in
 // your .go files.
-//
 package main
 
 import (
@@ -21,9 +20,11 @@ import (
 )
 
 var (
-	optPath  = ""
-	optName  = ""
-	optPlain = false
+	optPath   = ""
+	optName   = ""
+	optPlain  = false
+	optPkg    = ""
+	optFormat = "troff"
 )
 
 func init() {
@@ -35,6 +36,14 @@ func init() {
 
 	// Treat comments as plain text rather than markdown.
 	flag.BoolVar(&optPlain, "plain", false, "plain text comments")
+
+	// Load every file of the package in this directory instead of the
+	// single files given as arguments.
+	flag.StringVar(&optPkg, "pkg", "", "package directory")
+
+	// Select the output format: troff for man(7), mdoc for BSD's mdoc(7)
+	// macros, or md for CommonMark.
+	flag.StringVar(&optFormat, "format", optFormat, fmt.Sprintf("output format (%s)", strings.Join(markup.Formats(), "|")))
 }
 
 type Builder struct {
@@ -49,8 +58,7 @@ func NewBuilder() *Builder {
 	result := new(Builder)
 	result.Tokenizer = markup.NewTokenizer()
 	result.Parser = markup.NewParser()
-	result.Writer = markup.NewTroffWriter()
-	result.Renderer = markup.NewTroffRenderer(result.Writer)
+	result.Writer, result.Renderer, _ = markup.NewFormat(optFormat)
 	return result
 }
 
@@ -59,6 +67,20 @@ func (b *Builder) Load(path string) error {
 	if err != nil {
 		return err
 	}
+	return b.load(file)
+}
+
+// LoadPackage loads every buildable file in the package directory dir, the
+// same way Load loads a single file.
+func (b *Builder) LoadPackage(dir string) error {
+	file, err := source.NewPackage(dir)
+	if err != nil {
+		return err
+	}
+	return b.load(file)
+}
+
+func (b *Builder) load(file *source.File) error {
 	b.File = file
 
 	if len(optName) > 0 {
@@ -73,8 +95,19 @@ func (b *Builder) Load(path string) error {
 }
 
 func (b *Builder) feedDocumentation() {
+	b.Renderer.Section("Name")
+	if optPlain {
+		b.Renderer.Text(b.File.Synopsis)
+	} else if tokens, err := b.Tokenizer.TokenizeString(b.File.Synopsis); err == nil {
+		markup.Render(b.Renderer, b.Parser.Parse(tokens))
+	}
+
+	if len(b.File.Doc) == 0 {
+		return
+	}
+
+	b.Renderer.Section("Description")
 	if optPlain {
-		b.Renderer.Section("Name")
 		b.Renderer.Text(b.File.Doc)
 		return
 	}
@@ -83,8 +116,6 @@ func (b *Builder) feedDocumentation() {
 	if err != nil {
 		return
 	}
-
-	b.Renderer.Section("Name")
 	markup.Render(b.Renderer, b.Parser.Parse(tokens))
 }
 
@@ -146,6 +177,9 @@ func (b *Builder) feedOptions() {
 		if len(textType) > 0 {
 			b.Renderer.Text(textType)
 		}
+		if len(opt.Note) > 0 {
+			b.Renderer.TextUnderline(opt.Note)
+		}
 		if len(tokens) > 0 {
 			markup.Render(b.Renderer, b.Parser.ParsePart(tokens))
 		}
@@ -180,31 +214,89 @@ func usage() {
 	})
 }
 
+func dstExt() string {
+	if optFormat == "md" {
+		return "md"
+	}
+	return "1"
+}
+
+func build(srcPath string, load func(*Builder) error) {
+	builder := NewBuilder()
+
+	if err := load(builder); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Could not open '%s': %s\n", srcPath, err)
+		return
+	}
+
+	dstPath := fmt.Sprintf("%s.%s", builder.File.Name, dstExt())
+	if len(optPath) > 0 {
+		dstPath = path.Join(optPath, dstPath)
+	}
+
+	if err := builder.Save(dstPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Could not save '%s': %s\n", dstPath, err)
+		return
+	}
+	fmt.Printf("%s -> %s\n", srcPath, dstPath)
+
+	for _, cmd := range builder.File.Commands {
+		buildCommand(builder.File, cmd)
+	}
+}
+
+// buildCommand saves a short, separate man page for a cobra subcommand
+// discovered via an AddCommand call, the way e.g. git-push(1) ships
+// alongside git(1).
+func buildCommand(parent *source.File, cmd *source.Command) {
+	if len(cmd.Name) == 0 {
+		return
+	}
+
+	name := fmt.Sprintf("%s-%s", parent.Name, cmd.Name)
+
+	sub := NewBuilder()
+	sub.Writer.WriteTitle(name)
+	sub.Writer.WriteDate(parent.Time)
+	sub.Renderer.Section("Name")
+	sub.Renderer.Text(cmd.Short)
+	sub.Renderer.Section("Synopsis")
+	sub.Renderer.Text(fmt.Sprintf("%s %s", parent.Name, cmd.Name))
+	sub.Renderer.TextUnderline("[argument...]")
+	sub.Renderer.Break()
+
+	dstPath := fmt.Sprintf("%s.%s", name, dstExt())
+	if len(optPath) > 0 {
+		dstPath = path.Join(optPath, dstPath)
+	}
+
+	if err := sub.Save(dstPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Could not save '%s': %s\n", dstPath, err)
+		return
+	}
+	fmt.Printf("%s (subcommand) -> %s\n", cmd.Name, dstPath)
+}
+
 func main() {
 	flag.Usage = usage
 	flag.Parse()
+
+	if !markup.HasFormat(optFormat) {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (available: %s)\n", optFormat, strings.Join(markup.Formats(), ", "))
+		os.Exit(1)
+	}
+
+	if len(optPkg) > 0 {
+		build(optPkg, func(b *Builder) error { return b.LoadPackage(optPkg) })
+		return
+	}
+
 	if flag.NArg() == 0 {
 		flag.Usage()
 		return
 	}
 
 	for _, srcPath := range flag.Args() {
-		builder := NewBuilder()
-
-		if err := builder.Load(srcPath); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: Could not open file '%s': %s\n", srcPath, err)
-			continue
-		}
-
-		dstPath := fmt.Sprintf("%s.1", builder.File.Name)
-		if len(optPath) > 0 {
-			dstPath = path.Join(optPath, dstPath)
-		}
-
-		if err := builder.Save(dstPath); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: Could not save '%s': %s\n", dstPath, err)
-			continue
-		}
-		fmt.Printf("%s -> %s\n", srcPath, dstPath)
+		build(srcPath, func(b *Builder) error { return b.Load(srcPath) })
 	}
 }
@@ -0,0 +1,22 @@
+package markup
+
+import (
+	"bytes"
+	"io"
+)
+
+// buffer is embedded by format-specific Writers to provide the
+// WriteString/WriteTo part of the Writer interface, so every format only
+// has to implement WriteTitle and WriteDate itself.
+type buffer struct {
+	buf bytes.Buffer
+}
+
+func (b *buffer) WriteString(s string) {
+	b.buf.WriteString(s)
+}
+
+func (b *buffer) WriteTo(dst io.Writer) (int64, error) {
+	n, err := dst.Write(b.buf.Bytes())
+	return int64(n), err
+}
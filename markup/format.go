@@ -0,0 +1,52 @@
+package markup
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Format pairs the constructors needed to produce one output format: a
+// Writer to buffer and serialize the result, and a Renderer that turns a
+// parsed Node tree into that Writer's markup.
+type Format struct {
+	NewWriter   func() Writer
+	NewRenderer func(Writer) Renderer
+}
+
+var formats = make(map[string]Format)
+
+// RegisterFormat makes a format available under name, for later lookup by
+// NewFormat. It is meant to be called from an init() function, so third
+// parties can add their own output formats from outside the markup package
+// just by importing a package that registers one.
+func RegisterFormat(name string, format Format) {
+	formats[name] = format
+}
+
+// HasFormat reports whether name has been registered with RegisterFormat.
+func HasFormat(name string) bool {
+	_, ok := formats[name]
+	return ok
+}
+
+// Formats returns the names of all registered formats, sorted
+// alphabetically.
+func Formats() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewFormat returns a fresh Writer/Renderer pair for the format registered
+// under name.
+func NewFormat(name string) (Writer, Renderer, error) {
+	format, ok := formats[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("markup: unknown format %q", name)
+	}
+	w := format.NewWriter()
+	return w, format.NewRenderer(w), nil
+}
@@ -0,0 +1,77 @@
+package markup
+
+import (
+	"fmt"
+	"time"
+)
+
+func init() {
+	RegisterFormat("md", Format{
+		NewWriter:   func() Writer { return NewMarkdownWriter() },
+		NewRenderer: func(w Writer) Renderer { return NewMarkdownRenderer(w) },
+	})
+}
+
+// MarkdownWriter buffers a CommonMark document as it is produced by a
+// MarkdownRenderer.
+type MarkdownWriter struct {
+	buffer
+}
+
+func NewMarkdownWriter() *MarkdownWriter {
+	return new(MarkdownWriter)
+}
+
+func (w *MarkdownWriter) WriteTitle(name string) {
+	fmt.Fprintf(&w.buf, "# %s\n\n", name)
+}
+
+func (w *MarkdownWriter) WriteDate(t time.Time) {
+	fmt.Fprintf(&w.buf, "<!-- %s -->\n\n", t.Format("2006-01-02"))
+}
+
+// MarkdownRenderer renders a Node tree as CommonMark, so generated pages
+// drop straight into READMEs and web docs.
+type MarkdownRenderer struct {
+	w Writer
+}
+
+func NewMarkdownRenderer(w Writer) *MarkdownRenderer {
+	return &MarkdownRenderer{w: w}
+}
+
+func (r *MarkdownRenderer) Section(title string) {
+	r.w.WriteString(fmt.Sprintf("\n## %s\n\n", title))
+}
+
+func (r *MarkdownRenderer) Heading(title string) {
+	r.w.WriteString(fmt.Sprintf("\n### %s\n\n", title))
+}
+
+func (r *MarkdownRenderer) Text(text string) {
+	r.w.WriteString(text)
+}
+
+func (r *MarkdownRenderer) TextBold(text string) {
+	r.w.WriteString(fmt.Sprintf("**%s**", text))
+}
+
+func (r *MarkdownRenderer) TextUnderline(text string) {
+	r.w.WriteString(fmt.Sprintf("_%s_", text))
+}
+
+func (r *MarkdownRenderer) TextCode(text string) {
+	r.w.WriteString(fmt.Sprintf("`%s`", text))
+}
+
+func (r *MarkdownRenderer) CodeStart() {
+	r.w.WriteString("\n```\n")
+}
+
+func (r *MarkdownRenderer) CodeEnd() {
+	r.w.WriteString("```\n\n")
+}
+
+func (r *MarkdownRenderer) Break() {
+	r.w.WriteString("\n\n")
+}
@@ -0,0 +1,78 @@
+package markup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func init() {
+	RegisterFormat("mdoc", Format{
+		NewWriter:   func() Writer { return NewMdocWriter() },
+		NewRenderer: func(w Writer) Renderer { return NewMdocRenderer(w) },
+	})
+}
+
+// MdocWriter buffers mdoc(7) source, BSD's semantic man macro set, as it is
+// produced by an MdocRenderer. mdoc(7) is preferred on OpenBSD and macOS and
+// produces better-structured output than man(7).
+type MdocWriter struct {
+	buffer
+}
+
+func NewMdocWriter() *MdocWriter {
+	return new(MdocWriter)
+}
+
+func (w *MdocWriter) WriteTitle(name string) {
+	fmt.Fprintf(&w.buf, ".Dt %s 1\n.Os\n", strings.ToUpper(name))
+}
+
+func (w *MdocWriter) WriteDate(t time.Time) {
+	fmt.Fprintf(&w.buf, ".Dd %s\n", t.Format("January 2, 2006"))
+}
+
+// MdocRenderer renders a Node tree using mdoc(7) macros.
+type MdocRenderer struct {
+	w Writer
+}
+
+func NewMdocRenderer(w Writer) *MdocRenderer {
+	return &MdocRenderer{w: w}
+}
+
+func (r *MdocRenderer) Section(title string) {
+	r.w.WriteString(fmt.Sprintf("\n.Sh %s\n", strings.ToUpper(title)))
+}
+
+func (r *MdocRenderer) Heading(title string) {
+	r.w.WriteString(fmt.Sprintf("\n.Ss %s\n", title))
+}
+
+func (r *MdocRenderer) Text(text string) {
+	r.w.WriteString(text)
+}
+
+func (r *MdocRenderer) TextBold(text string) {
+	r.w.WriteString(fmt.Sprintf("\n.Em %s\n", text))
+}
+
+func (r *MdocRenderer) TextUnderline(text string) {
+	r.w.WriteString(fmt.Sprintf("\n.Ar %s\n", text))
+}
+
+func (r *MdocRenderer) TextCode(text string) {
+	r.w.WriteString(fmt.Sprintf("\n.Li %s\n", text))
+}
+
+func (r *MdocRenderer) CodeStart() {
+	r.w.WriteString("\n.Bd -literal\n")
+}
+
+func (r *MdocRenderer) CodeEnd() {
+	r.w.WriteString(".Ed\n")
+}
+
+func (r *MdocRenderer) Break() {
+	r.w.WriteString("\n.Pp\n")
+}
@@ -8,9 +8,12 @@ const (
 	NODE_GROUP = iota
 	NODE_BLOCK
 	NODE_SECTION
+	NODE_HEADING
 	NODE_TEXT
 	NODE_TEXTBOLD
 	NODE_TEXTUNDERLINE
+	NODE_CODEINLINE
+	NODE_CODEBLOCK
 	NODE_LIST
 	NODE_LISTITEM
 	NODE_SPACE
@@ -47,12 +50,18 @@ func (n *Node) String() string {
 		return "Block"
 	case NODE_SECTION:
 		return "Section"
+	case NODE_HEADING:
+		return "Heading"
 	case NODE_TEXT:
 		return "Text"
 	case NODE_TEXTBOLD:
 		return "TextBold"
 	case NODE_TEXTUNDERLINE:
 		return "TextUnderline"
+	case NODE_CODEINLINE:
+		return "TextCode"
+	case NODE_CODEBLOCK:
+		return "CodeBlock"
 	case NODE_LIST:
 		return "List"
 	case NODE_LISTITEM:
@@ -68,7 +77,7 @@ func (n *Node) AddChild(c *Node) {
 
 func (n *Node) IsTextNode() bool {
 	switch n.Kind {
-	case NODE_TEXT, NODE_TEXTBOLD, NODE_TEXTUNDERLINE:
+	case NODE_TEXT, NODE_TEXTBOLD, NODE_TEXTUNDERLINE, NODE_CODEINLINE:
 		return true
 	default:
 		return false
@@ -183,7 +192,20 @@ func (p *Parser) GetRoot() *Node {
 	return p.root
 }
 
+// Parse parses a full document, honoring TOKEN_SECTION/TOKEN_HEADING tokens
+// as section and heading boundaries.
 func (p *Parser) Parse(tokens []*Token) *Node {
+	return p.parse(tokens, true)
+}
+
+// ParsePart parses a fragment of markup, such as a single option's
+// description, where TOKEN_SECTION/TOKEN_HEADING tokens don't make sense and
+// are rendered as plain text instead.
+func (p *Parser) ParsePart(tokens []*Token) *Node {
+	return p.parse(tokens, false)
+}
+
+func (p *Parser) parse(tokens []*Token, allowSections bool) *Node {
 	p.root = NewNode(NODE_GROUP)
 	p.curr = p.root
 
@@ -265,8 +287,24 @@ func (p *Parser) Parse(tokens []*Token) *Node {
 			}
 			switch token.Kind {
 			case TOKEN_SECTION:
+				if !allowSections {
+					p.addNode(NODE_TEXT, token.Text)
+					break
+				}
 				p.closeAllGroups()
 				p.addNode(NODE_SECTION, token.Text)
+			case TOKEN_HEADING:
+				if !allowSections {
+					p.addNode(NODE_TEXT, token.Text)
+					break
+				}
+				p.closeAllGroups()
+				p.addNode(NODE_HEADING, token.Text)
+			case TOKEN_CODE:
+				if p.curr.Kind != NODE_CODEBLOCK {
+					p.curr = p.addNode(NODE_CODEBLOCK)
+				}
+				p.addNode(NODE_TEXT, token.Text)
 			case TOKEN_TEXT:
 				p.addNode(NODE_TEXT, token.Text)
 			case TOKEN_STAR:
@@ -279,6 +317,11 @@ func (p *Parser) Parse(tokens []*Token) *Node {
 					text, _ := group.Next(), group.Next()
 					p.addNode(NODE_TEXTUNDERLINE, text.Text)
 				}
+			case TOKEN_BACKTICK:
+				if group.Tokens().Are(TOKEN_TEXT, TOKEN_BACKTICK) {
+					text, _ := group.Next(), group.Next()
+					p.addNode(NODE_CODEINLINE, text.Text)
+				}
 			}
 		}
 
@@ -289,4 +332,4 @@ func (p *Parser) Parse(tokens []*Token) *Node {
 		lastLevel = group.level
 	}
 	return p.root
-}
\ No newline at end of file
+}
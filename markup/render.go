@@ -0,0 +1,75 @@
+package markup
+
+import (
+	"io"
+	"time"
+)
+
+// Writer receives the final, format-specific document produced by a
+// Renderer and knows how to serialize it.
+type Writer interface {
+	io.WriterTo
+
+	WriteTitle(name string)
+	WriteDate(t time.Time)
+	WriteString(s string)
+}
+
+// Renderer turns a parsed Node tree into the markup of one output format.
+// Implementations write their output to the Writer they were constructed
+// with.
+type Renderer interface {
+	Section(title string)
+	Heading(title string)
+	Text(text string)
+	TextBold(text string)
+	TextUnderline(text string)
+	TextCode(text string)
+	CodeStart()
+	CodeEnd()
+	Break()
+}
+
+// Render walks the Node tree produced by Parser.Parse/ParsePart and feeds it
+// into r.
+func Render(r Renderer, n *Node) {
+	switch n.Kind {
+	case NODE_SECTION:
+		r.Section(n.Text)
+	case NODE_HEADING:
+		r.Heading(n.Text)
+	case NODE_TEXT:
+		r.Text(n.Text)
+	case NODE_TEXTBOLD:
+		r.TextBold(n.Text)
+	case NODE_TEXTUNDERLINE:
+		r.TextUnderline(n.Text)
+	case NODE_CODEINLINE:
+		r.TextCode(n.Text)
+	case NODE_SPACE:
+		r.Text(" ")
+	case NODE_BREAK:
+		r.Break()
+	case NODE_LISTITEM:
+		r.Text("- ")
+		r.Text(n.Text)
+	case NODE_CODEBLOCK:
+		r.CodeStart()
+		for _, c := range n.Childs {
+			Render(r, c)
+			r.Break()
+		}
+		r.CodeEnd()
+		return
+	}
+
+	for _, c := range n.Childs {
+		Render(r, c)
+	}
+}
+
+// Save writes w's buffered document to dst.
+func Save(w Writer, dst io.Writer) error {
+	_, err := w.WriteTo(dst)
+	return err
+}
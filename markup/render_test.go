@@ -0,0 +1,37 @@
+package markup
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestRenderListItem guards against list items being rendered as a bare "-"
+// marker with their text silently dropped.
+func TestRenderListItem(t *testing.T) {
+	tz := NewTokenizer()
+	tokens, err := tz.TokenizeString("- fast mode for quick runs\n- slow mode for thorough runs\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := NewParser().Parse(tokens)
+
+	w, r, err := NewFormat("md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	Render(r, root)
+
+	var buf bytes.Buffer
+	if _, err := w.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"fast mode for quick runs", "slow mode for thorough runs"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rendered output %q does not contain %q", out, want)
+		}
+	}
+}
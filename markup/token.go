@@ -0,0 +1,48 @@
+package markup
+
+// Token kinds produced by the Tokenizer and consumed by the Parser.
+const (
+	TOKEN_TEXT = iota
+	TOKEN_STAR
+	TOKEN_UNDERLINE
+	TOKEN_INDENT
+	TOKEN_EOL
+	TOKEN_SECTION
+	TOKEN_HEADING
+	TOKEN_CODE
+	TOKEN_BACKTICK
+	TOKEN_BLOCKITEM
+	TOKEN_LISTITEM
+)
+
+type Token struct {
+	Kind int
+	Text string
+}
+
+func NewToken(kind int) *Token {
+	return NewTokenWithText(kind, "")
+}
+
+func NewTokenWithText(kind int, text string) *Token {
+	return &Token{kind, text}
+}
+
+func (t *Token) Is(kind int) bool {
+	return t.Kind == kind
+}
+
+type Tokens []*Token
+
+// Are reports whether the first len(kinds) tokens match kinds, in order.
+func (t Tokens) Are(kinds ...int) bool {
+	if len(t) < len(kinds) {
+		return false
+	}
+	for i, kind := range kinds {
+		if t[i].Kind != kind {
+			return false
+		}
+	}
+	return true
+}
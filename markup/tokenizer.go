@@ -0,0 +1,135 @@
+package markup
+
+import (
+	"strings"
+)
+
+// tokenizerIndentWidth is the number of spaces that counts as one
+// indentation level, matching the convention godoc uses for preformatted
+// code sections in doc comments.
+const tokenizerIndentWidth = 4
+
+// Tokenizer turns doc comment text into the flat Token stream the Parser
+// consumes. It understands plain paragraphs, "*bold*"/"_underline_" runs,
+// "- " list items, indented code blocks and godoc-style ALL CAPS headings.
+type Tokenizer struct{}
+
+func NewTokenizer() *Tokenizer {
+	return new(Tokenizer)
+}
+
+// TokenizeString converts s into a token stream. Every line produces zero or
+// more TOKEN_INDENT tokens, content tokens and a closing TOKEN_EOL. A blank
+// line produces a bare TOKEN_EOL, which tells the Parser to close any open
+// groups.
+func (t *Tokenizer) TokenizeString(s string) (Tokens, error) {
+	tokens := make(Tokens, 0)
+
+	for _, line := range strings.Split(s, "\n") {
+		trimmed := strings.TrimRight(line, " \t")
+		if len(trimmed) == 0 {
+			tokens = append(tokens, NewToken(TOKEN_EOL))
+			continue
+		}
+
+		level, rest := tokenizerIndent(trimmed)
+		for i := 0; i < level; i++ {
+			tokens = append(tokens, NewToken(TOKEN_INDENT))
+		}
+
+		switch {
+		case strings.HasPrefix(rest, "- ") || strings.HasPrefix(rest, "* "):
+			tokens = append(tokens, NewTokenWithText(TOKEN_LISTITEM, strings.TrimSpace(rest[2:])))
+		case level > 0:
+			tokens = append(tokens, NewTokenWithText(TOKEN_CODE, rest))
+		case tokenizerIsHeading(rest):
+			tokens = append(tokens, NewTokenWithText(TOKEN_HEADING, rest))
+		default:
+			tokens = append(tokens, t.tokenizeText(rest)...)
+		}
+		tokens = append(tokens, NewToken(TOKEN_EOL))
+	}
+	return tokens, nil
+}
+
+// tokenizerIndent reports the indentation level of line (each tab or each
+// run of tokenizerIndentWidth spaces counts as one level) and returns the
+// line with that indentation removed.
+func tokenizerIndent(line string) (int, string) {
+	level := 0
+	for {
+		switch {
+		case strings.HasPrefix(line, "\t"):
+			line = line[1:]
+			level++
+		case strings.HasPrefix(line, strings.Repeat(" ", tokenizerIndentWidth)):
+			line = line[tokenizerIndentWidth:]
+			level++
+		default:
+			return level, line
+		}
+	}
+}
+
+// tokenizerIsHeading reports whether line looks like a godoc heading: a run
+// of ALL CAPS words and digits with no punctuation.
+func tokenizerIsHeading(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if len(trimmed) == 0 || strings.HasSuffix(trimmed, ".") || strings.HasSuffix(trimmed, ",") {
+		return false
+	}
+	for _, r := range trimmed {
+		switch {
+		case r == ' ':
+		case r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// tokenizeText splits a plain text line into TOKEN_TEXT runs, recognizing
+// "*bold*", "_underline_" and “ `code` “ spans.
+func (t *Tokenizer) tokenizeText(line string) Tokens {
+	tokens := make(Tokens, 0)
+	runes := []rune(line)
+
+	var buf strings.Builder
+	flush := func() {
+		if buf.Len() > 0 {
+			tokens = append(tokens, NewTokenWithText(TOKEN_TEXT, buf.String()))
+			buf.Reset()
+		}
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r != '*' && r != '_' && r != '`' {
+			buf.WriteRune(r)
+			continue
+		}
+
+		kind := TOKEN_STAR
+		switch r {
+		case '_':
+			kind = TOKEN_UNDERLINE
+		case '`':
+			kind = TOKEN_BACKTICK
+		}
+		end := strings.IndexRune(string(runes[i+1:]), r)
+		if end <= 0 {
+			buf.WriteRune(r)
+			continue
+		}
+
+		flush()
+		tokens = append(tokens, NewToken(kind))
+		tokens = append(tokens, NewTokenWithText(TOKEN_TEXT, string(runes[i+1:i+1+end])))
+		tokens = append(tokens, NewToken(kind))
+		i += end + 1
+	}
+	flush()
+	return tokens
+}
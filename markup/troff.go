@@ -0,0 +1,83 @@
+package markup
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func init() {
+	RegisterFormat("troff", Format{
+		NewWriter:   func() Writer { return NewTroffWriter() },
+		NewRenderer: func(w Writer) Renderer { return NewTroffRenderer(w) },
+	})
+}
+
+// TroffWriter buffers groff/troff man(7) source as it is produced by a
+// TroffRenderer.
+type TroffWriter struct {
+	buffer
+}
+
+func NewTroffWriter() *TroffWriter {
+	return new(TroffWriter)
+}
+
+func (w *TroffWriter) WriteTitle(name string) {
+	fmt.Fprintf(&w.buf, ".TH %s 1\n", strings.ToUpper(name))
+}
+
+func (w *TroffWriter) WriteDate(t time.Time) {
+	fmt.Fprintf(&w.buf, ".\\\" %s\n", t.Format("2006-01-02"))
+}
+
+// TroffRenderer renders a Node tree as man(7) troff requests.
+type TroffRenderer struct {
+	w Writer
+}
+
+func NewTroffRenderer(w Writer) *TroffRenderer {
+	return &TroffRenderer{w: w}
+}
+
+func (r *TroffRenderer) Section(title string) {
+	r.w.WriteString(fmt.Sprintf("\n.SH %s\n", strings.ToUpper(title)))
+}
+
+func (r *TroffRenderer) Heading(title string) {
+	r.w.WriteString(fmt.Sprintf("\n.SS %s\n", title))
+}
+
+func (r *TroffRenderer) Text(text string) {
+	r.w.WriteString(troffEscape(text))
+}
+
+func (r *TroffRenderer) TextBold(text string) {
+	r.w.WriteString(fmt.Sprintf("\\fB%s\\fR", troffEscape(text)))
+}
+
+func (r *TroffRenderer) TextUnderline(text string) {
+	r.w.WriteString(fmt.Sprintf("\\fI%s\\fR", troffEscape(text)))
+}
+
+func (r *TroffRenderer) TextCode(text string) {
+	r.w.WriteString(fmt.Sprintf("\\f(CR%s\\fR", troffEscape(text)))
+}
+
+func (r *TroffRenderer) CodeStart() {
+	r.w.WriteString("\n.nf\n\\f(CR\n")
+}
+
+func (r *TroffRenderer) CodeEnd() {
+	r.w.WriteString("\\fR\n.fi\n")
+}
+
+func (r *TroffRenderer) Break() {
+	r.w.WriteString("\n.PP\n")
+}
+
+// troffEscape escapes characters troff would otherwise interpret as request
+// syntax.
+func troffEscape(s string) string {
+	return strings.ReplaceAll(s, "-", "\\-")
+}
@@ -0,0 +1,108 @@
+package source
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// Command describes one cobra subcommand discovered via an AddCommand call.
+type Command struct {
+	Name  string // First word of cobra.Command.Use, e.g. "push".
+	Short string // cobra.Command.Short.
+}
+
+// findCommands walks file for AddCommand calls whose arguments are variables
+// declared with var as *cobra.Command composite literals, and returns the
+// Command each one describes. Literals passed to AddCommand directly, and
+// variables assigned with :=, are not recognized. A command
+// split across several files, the way cobra generators like to lay things
+// out, needs findCommands run once per file and its results merged, the
+// same way Options are.
+func findCommands(file *ast.File) []*Command {
+	vars := make(map[string]*ast.CompositeLit)
+	ast.Inspect(file, func(node ast.Node) bool {
+		spec, ok := node.(*ast.ValueSpec)
+		if !ok || len(spec.Names) != 1 || len(spec.Values) != 1 {
+			return true
+		}
+		if lit := cobraCommandLit(spec.Values[0]); lit != nil {
+			vars[spec.Names[0].Name] = lit
+		}
+		return true
+	})
+
+	commands := make([]*Command, 0)
+	seen := make(map[string]bool)
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "AddCommand" {
+			return true
+		}
+		for _, arg := range call.Args {
+			ident, ok := arg.(*ast.Ident)
+			if !ok || seen[ident.Name] {
+				continue
+			}
+			lit, ok := vars[ident.Name]
+			if !ok {
+				continue
+			}
+			seen[ident.Name] = true
+			commands = append(commands, newCommand(lit))
+		}
+		return true
+	})
+	return commands
+}
+
+// cobraCommandLit returns expr's underlying *ast.CompositeLit if expr is a
+// (possibly address-of) cobra.Command literal, and nil otherwise.
+func cobraCommandLit(expr ast.Expr) *ast.CompositeLit {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	sel, ok := lit.Type.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok || pkg.Name != "cobra" || sel.Sel.Name != "Command" {
+		return nil
+	}
+	return lit
+}
+
+func newCommand(lit *ast.CompositeLit) *Command {
+	cmd := new(Command)
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		value, ok := stringLit(kv.Value)
+		if !ok {
+			continue
+		}
+		switch key.Name {
+		case "Use":
+			if fields := strings.Fields(value); len(fields) > 0 {
+				cmd.Name = fields[0]
+			}
+		case "Short":
+			cmd.Short = value
+		}
+	}
+	return cmd
+}
@@ -3,10 +3,12 @@ package source
 import (
 	"errors"
 	"go/ast"
+	"go/doc"
 	"go/parser"
 	"go/token"
 	"os"
 	"path"
+	"strings"
 	"time"
 )
 
@@ -15,15 +17,17 @@ var (
 )
 
 type File struct {
-	Options []*Option // Options found in file.
-	Name    string    // Name of command.
-	Doc     string    // Comment preceding the "package" keyword.
-	Time    time.Time // Modification time.
+	Options  []*Option  // Options found in file.
+	Commands []*Command // Subcommands registered via cmd.AddCommand.
+	Name     string     // Name of command.
+	Synopsis string     // Leading sentence of the doc comment.
+	Doc      string     // Doc comment body, with the synopsis removed.
+	Time     time.Time  // Modification time.
 
 	// Unexported fields
 	fileSet  *token.FileSet
 	file     *ast.File
-	comments map[int]*ast.CommentGroup // map ending line -> comment group
+	comments ast.CommentMap
 }
 
 func NewFile(filePath string) (*File, error) {
@@ -52,68 +56,132 @@ func NewFile(filePath string) (*File, error) {
 	result.fileSet = fileSet
 	result.file = file
 
-	// The last comment group before a package declaration must contain the
-	// command description.
-	packageLine := 2
-	if packagePos := fileSet.Position(file.Package); packagePos.IsValid() {
-		packageLine = packagePos.Line
+	// Let go/doc build the package documentation instead of taking the
+	// comment group before "package" verbatim. This gives us godoc's
+	// conventions for free: the leading sentence becomes the synopsis,
+	// indented lines become preformatted code and ALL CAPS lines become
+	// headings.
+	// doc.AllDecls keeps unexported declarations (flag calls usually live in
+	// an unexported init/main), and doc.PreserveAST stops go/doc from taking
+	// ownership of the AST and stripping function bodies out from under the
+	// CommentMap/flag discovery that runs after this call.
+	pkgDoc, err := doc.NewFromFiles(fileSet, []*ast.File{file}, file.Name.Name, doc.AllDecls|doc.PreserveAST)
+	if err != nil {
+		return nil, err
 	}
+	result.Synopsis = doc.Synopsis(pkgDoc.Doc)
+	result.Doc = trimSynopsis(pkgDoc.Doc, result.Synopsis)
 
-	// Load comment groups and map them to their ending line number.
-	// We assume a comment belongs to a command line flag declaration if it
-	// ends on the previous line of the flag declaration.
-	result.comments = make(map[int]*ast.CommentGroup)
-	for _, group := range file.Comments {
-		pos := fileSet.Position(group.Pos())
-		end := fileSet.Position(group.End())
-
-		if pos.Line < packageLine {
-			result.Doc = group.Text()
-		}
-		result.comments[end.Line] = group
-	}
+	// ast.NewCommentMap attaches both lead and trailing comments to the
+	// declarations they belong to, which is far more robust than matching
+	// comments to flag calls by end line: it survives doc comments
+	// separated from the call by a blank line, flag calls that span
+	// several lines and /* ... */ block comments.
+	result.comments = ast.NewCommentMap(fileSet, file, file.Comments)
+	result.Commands = findCommands(file)
 
 	result.parseOptions()
 
 	return result, nil
 }
 
+// trimSynopsis removes synopsis from the start of text. doc.Synopsis joins
+// the lines of a wrapped first sentence with single spaces, so words are
+// matched one by one, skipping any whitespace in text between them. text is
+// returned unchanged if it doesn't start with synopsis.
+func trimSynopsis(text, synopsis string) string {
+	rest := text
+	for _, word := range strings.Fields(synopsis) {
+		rest = strings.TrimLeft(rest, " \t\n")
+		if !strings.HasPrefix(rest, word) {
+			return text
+		}
+		rest = rest[len(word):]
+	}
+	return strings.TrimLeft(rest, " \t\n")
+}
+
 func (f *File) parseOptions() {
 	options := make([]*Option, 0)
-
-	// Memorize options with variable names
 	bound := make(map[string]*Option)
+	appendOptions(f.fileSet, f.file, f.comments, &options, bound)
+	f.Options = options
+}
 
-	// Load all options in source file. This means, detect and parse
-	// all flag.Bool, flag.Duration, ... calls.
-	ast.Inspect(f.file, func(node ast.Node) bool {
-		if call, ok := node.(*ast.CallExpr); ok {
-			if opt, err := NewOptionFromCallExpr(f.fileSet, call); err == nil {
-				// Check if we have a comment that belongs to option
-				if comment, ok := f.comments[opt.Line-1]; ok {
-					opt.Doc = comment.Text()
-				}
+// appendOptions walks file looking for flag declarations recognized by any
+// registered Extractor (the stdlib flag package, pflag, urfave/cli, ...) and
+// appends the Option each one describes to *options. bound memorizes
+// options by the variable they're bound to, so that e.g. a long and a short
+// flag registered for the same variable are merged into one Option instead
+// of listed twice. It is shared by File.parseOptions and NewPackage, which
+// both need to assemble one Option list out of one or more files.
+func appendOptions(fileSet *token.FileSet, file *ast.File, comments ast.CommentMap, options *[]*Option, bound map[string]*Option) {
+	var stack []ast.Node
+	ast.Inspect(file, func(node ast.Node) bool {
+		if node == nil {
+			stack = stack[:len(stack)-1]
+			return false
+		}
+		stack = append(stack, node)
+
+		opt, err := extractOption(fileSet, node)
+		if err != nil {
+			return true
+		}
 
-				// Check if we already encountered an option bound to the
-				// variable.
-				if opt.Variable != "" {
-					if reg, ok := bound[opt.Variable]; ok {
-						// Merge currrent option with the one we already found
-						reg.merge(opt)
-						// Don't add the current option to the list, since the list
-						// already contains the struct stored in the map.
-						return true
-					} else {
-						// Register variable and the proceed to add option
-						// struct to the options list
-						bound[opt.Variable] = opt
-					}
+		// ast.NewCommentMap attaches comments to the statement or declaration
+		// that encloses the flag call (see commentOwner), never to the nested *ast.CallExpr extractOption
+		// matched on, so look the comments up on that enclosing node instead.
+		// Comments found there are either a lead comment (the flag's doc) or
+		// a trailing line comment (a short usage note printed next to it).
+		if owner := commentOwner(stack); owner != nil {
+			for _, group := range comments[owner] {
+				if fileSet.Position(group.Pos()).Line == fileSet.Position(owner.End()).Line {
+					opt.Note = group.Text()
+				} else {
+					opt.Doc = group.Text()
 				}
-				options = append(options, opt)
 			}
 		}
+
+		// Check if we already encountered an option bound to the variable.
+		if opt.Variable != "" {
+			if reg, ok := bound[opt.Variable]; ok {
+				// Merge currrent option with the one we already found. Don't
+				// add the current option to the list, since the list
+				// already contains the struct stored in the map.
+				reg.merge(opt)
+				return true
+			}
+			// Register variable and proceed to add the option struct to the
+			// options list.
+			bound[opt.Variable] = opt
+		}
+		*options = append(*options, opt)
 		return true
 	})
+}
 
-	f.Options = options
+// commentOwner returns the node nearest the top of stack (excluding the
+// current node itself, the last entry) that ast.NewCommentMap would have
+// attached a comment to, or nil if none of its ancestors are such a node.
+func commentOwner(stack []ast.Node) ast.Node {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch stack[i].(type) {
+		case *ast.ExprStmt, *ast.AssignStmt:
+			return stack[i]
+		case *ast.ValueSpec:
+			// A single, unparenthesized "var x = ..." declaration has its
+			// comments attached to the GenDecl, or inside a function to the
+			// DeclStmt wrapping it, rather than to the spec itself.
+			if decl, ok := stack[i-1].(*ast.GenDecl); ok && !decl.Lparen.IsValid() {
+				if stmt, ok := stack[i-2].(*ast.DeclStmt); ok {
+					return stmt
+				}
+				return decl
+			}
+			return stack[i]
+		}
+	}
+	return nil
 }
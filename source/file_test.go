@@ -0,0 +1,49 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestParseOptionsComments guards against comments attaching to the wrong
+// AST node: ast.NewCommentMap keys lead/trailing comments off the statement
+// or spec enclosing a flag call, never off the nested CallExpr itself.
+func TestParseOptionsComments(t *testing.T) {
+	src := `package main
+
+import "flag"
+
+var (
+	// debug enables debug output.
+	debug = flag.Bool("debug", false, "debug output") // short note
+)
+
+func f() {
+	// local var doc
+	x := flag.Int("x", 0, "an int") // local note
+	_ = x
+}
+`
+	path := filepath.Join(t.TempDir(), "sample.go")
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := NewFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(file.Options) != 2 {
+		t.Fatalf("got %d options, want 2", len(file.Options))
+	}
+
+	for _, opt := range file.Options {
+		if opt.Doc == "" {
+			t.Errorf("option %q: Doc is empty", opt.Name)
+		}
+		if opt.Note == "" {
+			t.Errorf("option %q: Note is empty", opt.Name)
+		}
+	}
+}
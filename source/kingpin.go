@@ -0,0 +1,80 @@
+package source
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// extractKingpin recognizes kingpin flag declarations, which are built as a
+// method chain rooted at a Flag(name, usage) call rather than a single call
+// or composite literal, e.g.:
+//
+//	kingpin.Flag("debug", "enable debug output").Short('d').Bool()
+//
+// so it walks back through the chain looking for the Flag(...) call (and
+// any Short(...) call) it's rooted at.
+func extractKingpin(fileSet *token.FileSet, node ast.Node) (*Option, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil, ErrNotOption
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, ErrNotOption
+	}
+	typ, ok := flagConstructors[sel.Sel.Name]
+	if !ok {
+		return nil, ErrNotOption
+	}
+
+	flagCall, short := kingpinFlagCall(sel.X)
+	if flagCall == nil || len(flagCall.Args) < 2 {
+		return nil, ErrNotOption
+	}
+
+	name, ok := stringLit(flagCall.Args[0])
+	if !ok {
+		return nil, ErrNotOption
+	}
+
+	result := &Option{
+		Type:  typ,
+		Name:  name,
+		Short: short,
+		Line:  fileSet.Position(call.Pos()).Line,
+	}
+	if usage, ok := stringLit(flagCall.Args[1]); ok {
+		result.Usage = usage
+	}
+	return result, nil
+}
+
+// kingpinFlagCall walks back through a kingpin method chain rooted at expr
+// looking for the Flag(name, usage) call it was built from, returning it
+// along with the short flag character set via an intervening Short('x')
+// call, if any.
+func kingpinFlagCall(expr ast.Expr) (*ast.CallExpr, string) {
+	short := ""
+	for {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return nil, ""
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil, ""
+		}
+		switch sel.Sel.Name {
+		case "Flag":
+			return call, short
+		case "Short":
+			if len(call.Args) == 1 {
+				if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.CHAR {
+					short = strings.Trim(lit.Value, "'")
+				}
+			}
+		}
+		expr = sel.X
+	}
+}
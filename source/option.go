@@ -0,0 +1,157 @@
+package source
+
+import (
+	"errors"
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+var (
+	ErrNotOption = errors.New("not an option")
+)
+
+// flagConstructors maps the name of a flag package function to the Go type
+// of the value it parses.
+var flagConstructors = map[string]string{
+	"Bool":     "Bool",
+	"Duration": "Duration",
+	"Float64":  "Float64",
+	"Int":      "Int",
+	"Int64":    "Int64",
+	"String":   "String",
+	"Uint":     "Uint",
+	"Uint64":   "Uint64",
+}
+
+// Option represents one command line flag found in a source file.
+type Option struct {
+	Name     string // Flag name, e.g. "verbose".
+	Short    string // Short flag name, e.g. "v". Empty if there is none.
+	Type     string // Go type of the flag value, e.g. "Bool".
+	Doc      string // Lead comment found above the flag declaration.
+	Note     string // Trailing line comment next to the flag declaration.
+	Usage    string // Usage string passed to the flag constructor.
+	Variable string // Name of the variable the flag is bound to, if any.
+	Line     int    // Line the flag was declared on.
+}
+
+// merge folds another Option describing the same variable into o. This
+// happens when a flag is registered twice under a long and a short name,
+// e.g. flag.BoolVar(&v, "verbose", ...); flag.BoolVar(&v, "v", ...).
+func (o *Option) merge(other *Option) {
+	if len(other.Name) < len(o.Name) {
+		o.Short = other.Name
+	} else {
+		o.Short, o.Name = o.Name, other.Name
+	}
+	if len(o.Doc) == 0 {
+		o.Doc = other.Doc
+	}
+	if len(o.Note) == 0 {
+		o.Note = other.Note
+	}
+	if len(o.Usage) == 0 {
+		o.Usage = other.Usage
+	}
+}
+
+// NewOptionFromCallExpr inspects call and, if it is a call to one of the
+// flag package's constructors (flag.Bool, flag.StringVar, ...), returns the
+// Option it declares.
+func NewOptionFromCallExpr(fileSet *token.FileSet, call *ast.CallExpr) (*Option, error) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, ErrNotOption
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok || pkg.Name != "flag" {
+		return nil, ErrNotOption
+	}
+
+	name := sel.Sel.Name
+	bound := strings.HasSuffix(name, "Var")
+	typ, ok := flagConstructors[strings.TrimSuffix(name, "Var")]
+	if !ok {
+		return nil, ErrNotOption
+	}
+
+	args := call.Args
+	result := &Option{
+		Type: typ,
+		Line: fileSet.Position(call.Pos()).Line,
+	}
+
+	if bound {
+		if len(args) < 3 {
+			return nil, ErrNotOption
+		}
+		if unary, ok := args[0].(*ast.UnaryExpr); ok {
+			if ident, ok := unary.X.(*ast.Ident); ok {
+				result.Variable = ident.Name
+			}
+		}
+		args = args[1:]
+	}
+	if len(args) < 2 {
+		return nil, ErrNotOption
+	}
+
+	lit, ok := args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return nil, ErrNotOption
+	}
+	result.Name = strings.Trim(lit.Value, "\"")
+
+	if usage, ok := args[len(args)-1].(*ast.BasicLit); ok && usage.Kind == token.STRING {
+		result.Usage = strings.Trim(usage.Value, "\"")
+	}
+
+	return result, nil
+}
+
+// Extractor inspects one ast.Node found while walking a source file and
+// returns the Option it declares, or ErrNotOption if node isn't a flag
+// declaration this extractor recognizes. Registering one with
+// RegisterExtractor teaches mango about a flag library without having to
+// fork the source package.
+type Extractor func(fileSet *token.FileSet, node ast.Node) (*Option, error)
+
+// extractors holds every registered Extractor. The first one to recognize a
+// node wins; extractStdFlag only matches calls on a package literally named
+// "flag", so the built-ins never shadow one another regardless of order.
+// RegisterExtractor appends, so built-ins always run before extractors a
+// caller registers afterwards.
+var extractors = []Extractor{
+	extractStdFlag,
+	extractPflag,
+	extractUrfaveFlag,
+	extractKingpin,
+}
+
+// RegisterExtractor adds extractor to the list mango tries for every
+// ast.Node it encounters while looking for flag declarations.
+func RegisterExtractor(extractor Extractor) {
+	extractors = append(extractors, extractor)
+}
+
+// extractOption runs every registered Extractor against node and returns
+// the Option reported by the first one that recognizes it.
+func extractOption(fileSet *token.FileSet, node ast.Node) (*Option, error) {
+	for _, extractor := range extractors {
+		if opt, err := extractor(fileSet, node); err == nil {
+			return opt, nil
+		}
+	}
+	return nil, ErrNotOption
+}
+
+// extractStdFlag recognizes calls to the stdlib flag package's constructors
+// (flag.Bool, flag.StringVar, ...).
+func extractStdFlag(fileSet *token.FileSet, node ast.Node) (*Option, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok {
+		return nil, ErrNotOption
+	}
+	return NewOptionFromCallExpr(fileSet, call)
+}
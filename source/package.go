@@ -0,0 +1,98 @@
+package source
+
+import (
+	"go/ast"
+	"go/build"
+	"go/doc"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// NewPackage loads every buildable .go file in dir and merges them into a
+// single File, the way a command split across main.go, flags.go and
+// cmd_*.go would be built by `go build`: _test.go files are skipped, and so
+// is any file whose // +build or //go:build constraint excludes the host.
+func NewPackage(dir string) (*File, error) {
+	result := new(File)
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, ErrFileType
+	}
+	result.Time = info.ModTime()
+	result.Name = filepath.Base(filepath.Clean(dir))
+
+	fileSet := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fileSet, dir, filterGoFiles(dir), parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err := mergePackages(pkgs)
+	if err != nil {
+		return nil, err
+	}
+	result.fileSet = fileSet
+
+	// doc.AllDecls keeps unexported declarations (flag calls usually live in
+	// an unexported init/main), and doc.PreserveAST stops go/doc from taking
+	// ownership of the AST and stripping function bodies out from under the
+	// CommentMap/flag discovery that runs after this call.
+	pkgDoc := doc.New(pkg, dir, doc.AllDecls|doc.PreserveAST)
+	result.Synopsis = doc.Synopsis(pkgDoc.Doc)
+	result.Doc = trimSynopsis(pkgDoc.Doc, result.Synopsis)
+
+	options := make([]*Option, 0)
+	commands := make([]*Command, 0)
+	bound := make(map[string]*Option)
+	for _, file := range pkg.Files {
+		comments := ast.NewCommentMap(fileSet, file, file.Comments)
+		appendOptions(fileSet, file, comments, &options, bound)
+		commands = append(commands, findCommands(file)...)
+	}
+	result.Options = options
+	result.Commands = commands
+
+	return result, nil
+}
+
+// filterGoFiles returns a parser.ParseDir filter that keeps exactly the
+// files `go build` would compile for the host: it drops _test.go files and
+// anything a // +build or //go:build constraint excludes.
+func filterGoFiles(dir string) func(os.FileInfo) bool {
+	return func(info os.FileInfo) bool {
+		name := info.Name()
+		if strings.HasSuffix(name, "_test.go") {
+			return false
+		}
+		match, err := build.Default.MatchFile(dir, name)
+		return err == nil && match
+	}
+}
+
+// mergePackages picks the package we should document out of the possibly
+// several ones parser.ParseDir found in dir (e.g. "main" alongside a stray
+// file declaring another package). mango builds command man
+// pages, so "main" always wins if present.
+func mergePackages(pkgs map[string]*ast.Package) (*ast.Package, error) {
+	if pkg, ok := pkgs["main"]; ok {
+		return pkg, nil
+	}
+
+	names := make([]string, 0, len(pkgs))
+	for name := range pkgs {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, ErrFileType
+	}
+	sort.Strings(names)
+	return pkgs[names[0]], nil
+}
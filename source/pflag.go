@@ -0,0 +1,76 @@
+package source
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// extractPflag recognizes calls to pflag's constructors (pflag.StringP,
+// pflag.StringVarP, ...) as well as the same calls made through a cobra
+// command's flag set (cmd.Flags().StringVarP, cmd.PersistentFlags().StringP,
+// ...). Unlike the stdlib flag package, pflag's constructors always take a
+// shorthand name alongside the long one.
+func extractPflag(fileSet *token.FileSet, node ast.Node) (*Option, error) {
+	call, ok := node.(*ast.CallExpr)
+	if !ok {
+		return nil, ErrNotOption
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, ErrNotOption
+	}
+	if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "flag" {
+		// Plain "flag.Xxx" calls belong to extractStdFlag.
+		return nil, ErrNotOption
+	}
+
+	name := sel.Sel.Name
+	bound := strings.HasSuffix(name, "VarP")
+	base := strings.TrimSuffix(name, "P")
+	if bound {
+		base = strings.TrimSuffix(base, "Var")
+	} else if !strings.HasSuffix(name, "P") {
+		return nil, ErrNotOption
+	}
+	typ, ok := flagConstructors[base]
+	if !ok {
+		return nil, ErrNotOption
+	}
+
+	args := call.Args
+	result := &Option{
+		Type: typ,
+		Line: fileSet.Position(call.Pos()).Line,
+	}
+
+	if bound {
+		if len(args) < 4 {
+			return nil, ErrNotOption
+		}
+		if unary, ok := args[0].(*ast.UnaryExpr); ok {
+			if ident, ok := unary.X.(*ast.Ident); ok {
+				result.Variable = ident.Name
+			}
+		}
+		args = args[1:]
+	}
+	if len(args) < 3 {
+		return nil, ErrNotOption
+	}
+
+	flagName, ok := args[0].(*ast.BasicLit)
+	if !ok || flagName.Kind != token.STRING {
+		return nil, ErrNotOption
+	}
+	result.Name = strings.Trim(flagName.Value, "\"")
+
+	if short, ok := args[1].(*ast.BasicLit); ok && short.Kind == token.STRING {
+		result.Short = strings.Trim(short.Value, "\"")
+	}
+	if usage, ok := args[len(args)-1].(*ast.BasicLit); ok && usage.Kind == token.STRING {
+		result.Usage = strings.Trim(usage.Value, "\"")
+	}
+
+	return result, nil
+}
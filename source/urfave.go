@@ -0,0 +1,96 @@
+package source
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// extractUrfaveFlag recognizes urfave/cli flag declarations, which are
+// composite literals such as:
+//
+//	cli.StringFlag{
+//		Name:    "lang, l",
+//		Value:   "english",
+//		Usage:   "language for the greeting",
+//	}
+//
+// rather than function calls, so it walks the literal's fields instead of
+// inspecting call arguments.
+func extractUrfaveFlag(fileSet *token.FileSet, node ast.Node) (*Option, error) {
+	lit, ok := node.(*ast.CompositeLit)
+	if !ok {
+		return nil, ErrNotOption
+	}
+	sel, ok := lit.Type.(*ast.SelectorExpr)
+	if !ok {
+		return nil, ErrNotOption
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok || pkg.Name != "cli" {
+		return nil, ErrNotOption
+	}
+	if !strings.HasSuffix(sel.Sel.Name, "Flag") {
+		return nil, ErrNotOption
+	}
+
+	result := &Option{
+		Type: strings.TrimSuffix(sel.Sel.Name, "Flag"),
+		Line: fileSet.Position(lit.Pos()).Line,
+	}
+
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		switch key.Name {
+		case "Name":
+			if value, ok := stringLit(kv.Value); ok {
+				names := strings.SplitN(value, ",", 2)
+				result.Name = strings.TrimSpace(names[0])
+				if len(names) > 1 {
+					result.Short = strings.TrimSpace(names[1])
+				}
+			}
+		case "Aliases":
+			if result.Short == "" {
+				result.Short = firstStringElement(kv.Value)
+			}
+		case "Usage":
+			if value, ok := stringLit(kv.Value); ok {
+				result.Usage = value
+			}
+		}
+	}
+
+	if len(result.Name) == 0 {
+		return nil, ErrNotOption
+	}
+	return result, nil
+}
+
+// stringLit reports the unquoted value of expr if it is a string literal.
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	return strings.Trim(lit.Value, "\""), true
+}
+
+// firstStringElement returns the unquoted value of the first string literal
+// in expr, which is expected to be a []string{...} composite literal such as
+// the urfave/cli Aliases field.
+func firstStringElement(expr ast.Expr) string {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok || len(lit.Elts) == 0 {
+		return ""
+	}
+	value, _ := stringLit(lit.Elts[0])
+	return value
+}